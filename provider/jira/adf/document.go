@@ -0,0 +1,151 @@
+// Package adf builds and validates documents in Atlassian Document Format
+// (ADF), the JSON-based rich text representation JIRA's v3 API requires for
+// fields such as an issue's description and comment bodies.
+package adf
+
+// node types the builder and markdown converter in this package emit.
+// Validate accepts any of these plus the container types (doc, listItem,
+// tableRow, tableHeader, tableCell) that callers won't build directly but
+// may receive in a hand-authored ADF document.
+const (
+	TypeDoc          = "doc"
+	TypeParagraph    = "paragraph"
+	TypeText         = "text"
+	TypeHeading      = "heading"
+	TypeCodeBlock    = "codeBlock"
+	TypeBulletList   = "bulletList"
+	TypeOrderedList  = "orderedList"
+	TypeListItem     = "listItem"
+	TypeBlockquote   = "blockquote"
+	TypeTable        = "table"
+	TypeTableRow     = "tableRow"
+	TypeTableHeader  = "tableHeader"
+	TypeTableCell    = "tableCell"
+	TypeHardBreak    = "hardBreak"
+	TypeMediaSingle  = "mediaSingle"
+	TypeMedia        = "media"
+)
+
+// docVersion is the only ADF schema version this package produces.
+const docVersion = 1
+
+// Document incrementally builds an ADF document's top-level content nodes.
+// The zero value is not usable; create one with Doc().
+type Document struct {
+	content []map[string]interface{}
+}
+
+// Doc starts a new, empty ADF document.
+func Doc() *Document {
+	return &Document{}
+}
+
+// Paragraph appends a paragraph node containing a single unmarked text run.
+func (d *Document) Paragraph(text string) *Document {
+	d.content = append(d.content, paragraphNode(textNode(text)))
+	return d
+}
+
+// Heading appends a heading node at the given level (1-6).
+func (d *Document) Heading(level int, text string) *Document {
+	d.content = append(d.content, map[string]interface{}{
+		"type":    TypeHeading,
+		"attrs":   map[string]interface{}{"level": level},
+		"content": []map[string]interface{}{textNode(text)},
+	})
+	return d
+}
+
+// CodeBlock appends a fenced code block annotated with language.
+func (d *Document) CodeBlock(language, code string) *Document {
+	d.content = append(d.content, map[string]interface{}{
+		"type":    TypeCodeBlock,
+		"attrs":   map[string]interface{}{"language": language},
+		"content": []map[string]interface{}{textNode(code)},
+	})
+	return d
+}
+
+// BulletList appends an unordered list with one plain-text list item per
+// entry in items.
+func (d *Document) BulletList(items ...string) *Document {
+	d.content = append(d.content, listNode(TypeBulletList, items))
+	return d
+}
+
+// OrderedList appends an ordered list with one plain-text list item per
+// entry in items.
+func (d *Document) OrderedList(items ...string) *Document {
+	d.content = append(d.content, listNode(TypeOrderedList, items))
+	return d
+}
+
+// Table appends a table node built from rows, treating the first row as
+// the header row.
+func (d *Document) Table(rows [][]string) *Document {
+	d.content = append(d.content, tableNode(rows))
+	return d
+}
+
+// Build returns the finished document as the map[string]interface{} shape
+// JIRA's API expects, suitable for Payload.Description.
+func (d *Document) Build() map[string]interface{} {
+	return map[string]interface{}{
+		"version": docVersion,
+		"type":    TypeDoc,
+		"content": d.content,
+	}
+}
+
+func paragraphNode(inline ...map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"type":    TypeParagraph,
+		"content": inline,
+	}
+}
+
+func textNode(text string) map[string]interface{} {
+	return map[string]interface{}{
+		"type": TypeText,
+		"text": text,
+	}
+}
+
+func listNode(listType string, items []string) map[string]interface{} {
+	listItems := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		listItems = append(listItems, map[string]interface{}{
+			"type":    TypeListItem,
+			"content": []map[string]interface{}{paragraphNode(textNode(item))},
+		})
+	}
+	return map[string]interface{}{
+		"type":    listType,
+		"content": listItems,
+	}
+}
+
+func tableNode(rows [][]string) map[string]interface{} {
+	tableRows := make([]map[string]interface{}, 0, len(rows))
+	for rowIndex, row := range rows {
+		cellType := TypeTableCell
+		if rowIndex == 0 {
+			cellType = TypeTableHeader
+		}
+		cells := make([]map[string]interface{}, 0, len(row))
+		for _, cell := range row {
+			cells = append(cells, map[string]interface{}{
+				"type":    cellType,
+				"content": []map[string]interface{}{paragraphNode(textNode(cell))},
+			})
+		}
+		tableRows = append(tableRows, map[string]interface{}{
+			"type":    TypeTableRow,
+			"content": cells,
+		})
+	}
+	return map[string]interface{}{
+		"type":    TypeTable,
+		"content": tableRows,
+	}
+}
@@ -0,0 +1,219 @@
+package adf
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	headingPattern    = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	orderedItemPrefix = regexp.MustCompile(`^\d+\.\s+(.*)$`)
+	unorderedPrefixes = []string{"- ", "* ", "+ "}
+	codeFence         = regexp.MustCompile("^```\\s*([a-zA-Z0-9_+-]*)\\s*$")
+	imagePattern      = regexp.MustCompile(`!\[([^\]]*)\]\(([^)]*)\)`)
+	linkPattern       = regexp.MustCompile(`\[([^\]]*)\]\(([^)]*)\)`)
+	boldPattern       = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	italicPattern     = regexp.MustCompile(`\*([^*]+)\*`)
+	codeSpanPattern   = regexp.MustCompile("`([^`]+)`")
+)
+
+// FromMarkdown converts a Markdown document into ADF, supporting headings,
+// bold/italic/code marks, links, ordered/unordered lists, fenced code
+// blocks, blockquotes, and inline images. Source with no Markdown syntax
+// round-trips as a single plain-text paragraph, so this also serves as the
+// auto-wrap path for plain text descriptions.
+func FromMarkdown(source string) (map[string]interface{}, error) {
+	doc := Doc()
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			continue
+
+		case codeFence.MatchString(line):
+			language := codeFence.FindStringSubmatch(line)[1]
+			var code []string
+			i++
+			for i < len(lines) && !codeFence.MatchString(lines[i]) {
+				code = append(code, lines[i])
+				i++
+			}
+			doc.CodeBlock(language, strings.Join(code, "\n"))
+
+		case headingPattern.MatchString(line):
+			match := headingPattern.FindStringSubmatch(line)
+			level := len(match[1])
+			doc.Heading(level, stripInlineMarks(match[2]))
+
+		case strings.HasPrefix(strings.TrimSpace(line), ">"):
+			doc.content = append(doc.content, blockquoteNode(stripInlineMarks(strings.TrimPrefix(strings.TrimSpace(line), ">"))))
+
+		case isUnorderedItem(line):
+			items := []string{stripInlineMarks(unorderedItemText(line))}
+			for i+1 < len(lines) && isUnorderedItem(lines[i+1]) {
+				i++
+				items = append(items, stripInlineMarks(unorderedItemText(lines[i])))
+			}
+			doc.BulletList(items...)
+
+		case orderedItemPrefix.MatchString(line):
+			items := []string{stripInlineMarks(orderedItemPrefix.FindStringSubmatch(line)[1])}
+			for i+1 < len(lines) && orderedItemPrefix.MatchString(lines[i+1]) {
+				i++
+				items = append(items, stripInlineMarks(orderedItemPrefix.FindStringSubmatch(lines[i])[1]))
+			}
+			doc.OrderedList(items...)
+
+		case imagePattern.MatchString(strings.TrimSpace(line)) && imagePattern.FindString(strings.TrimSpace(line)) == strings.TrimSpace(line):
+			match := imagePattern.FindStringSubmatch(line)
+			doc.content = append(doc.content, mediaSingleNode(match[1], match[2]))
+
+		default:
+			doc.content = append(doc.content, paragraphNode(inlineNodes(line)...))
+		}
+	}
+
+	return doc.Build(), nil
+}
+
+func isUnorderedItem(line string) bool {
+	trimmed := strings.TrimLeft(line, " ")
+	for _, prefix := range unorderedPrefixes {
+		if strings.HasPrefix(trimmed, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func unorderedItemText(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	for _, prefix := range unorderedPrefixes {
+		if strings.HasPrefix(trimmed, prefix) {
+			return strings.TrimPrefix(trimmed, prefix)
+		}
+	}
+	return trimmed
+}
+
+func blockquoteNode(text string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":    TypeBlockquote,
+		"content": []map[string]interface{}{paragraphNode(textNode(text))},
+	}
+}
+
+func mediaSingleNode(alt, url string) map[string]interface{} {
+	return map[string]interface{}{
+		"type": TypeMediaSingle,
+		"content": []map[string]interface{}{{
+			"type": TypeMedia,
+			"attrs": map[string]interface{}{
+				"type": "external",
+				"url":  url,
+				"alt":  alt,
+			},
+		}},
+	}
+}
+
+// inlineNodes splits a line of Markdown into ADF text and media nodes,
+// applying strong/em/code marks and link/image attrs. It is intentionally
+// simple: nodes are resolved in priority order (image, then code, then
+// bold, then italic, then links) rather than supporting arbitrary nesting.
+// Images are checked before links because image syntax ("![alt](url)") is
+// link syntax with a leading "!", so linkPattern would otherwise match the
+// "[alt](url)" portion and strip the "!" into a stray literal character.
+func inlineNodes(line string) []map[string]interface{} {
+	if loc := imagePattern.FindStringSubmatchIndex(line); loc != nil {
+		before := line[:loc[0]]
+		alt := line[loc[2]:loc[3]]
+		url := line[loc[4]:loc[5]]
+		after := line[loc[1]:]
+
+		var nodes []map[string]interface{}
+		if before != "" {
+			nodes = append(nodes, inlineNodes(before)...)
+		}
+		nodes = append(nodes, map[string]interface{}{
+			"type": TypeMedia,
+			"attrs": map[string]interface{}{
+				"type": "external",
+				"url":  url,
+				"alt":  alt,
+			},
+		})
+		if after != "" {
+			nodes = append(nodes, inlineNodes(after)...)
+		}
+		return nodes
+	}
+
+	if loc := linkPattern.FindStringSubmatchIndex(line); loc != nil {
+		before := line[:loc[0]]
+		text := line[loc[2]:loc[3]]
+		url := line[loc[4]:loc[5]]
+		after := line[loc[1]:]
+
+		var nodes []map[string]interface{}
+		if before != "" {
+			nodes = append(nodes, inlineNodes(before)...)
+		}
+		nodes = append(nodes, map[string]interface{}{
+			"type": TypeText,
+			"text": text,
+			"marks": []map[string]interface{}{{
+				"type":  "link",
+				"attrs": map[string]interface{}{"href": url},
+			}},
+		})
+		if after != "" {
+			nodes = append(nodes, inlineNodes(after)...)
+		}
+		return nodes
+	}
+
+	if loc := codeSpanPattern.FindStringSubmatchIndex(line); loc != nil {
+		return splitAroundMark(line, loc, "code")
+	}
+	if loc := boldPattern.FindStringSubmatchIndex(line); loc != nil {
+		return splitAroundMark(line, loc, "strong")
+	}
+	if loc := italicPattern.FindStringSubmatchIndex(line); loc != nil {
+		return splitAroundMark(line, loc, "em")
+	}
+
+	return []map[string]interface{}{textNode(line)}
+}
+
+func splitAroundMark(line string, loc []int, markType string) []map[string]interface{} {
+	before, text, after := line[:loc[0]], line[loc[2]:loc[3]], line[loc[1]:]
+
+	var nodes []map[string]interface{}
+	if before != "" {
+		nodes = append(nodes, inlineNodes(before)...)
+	}
+	nodes = append(nodes, map[string]interface{}{
+		"type":  TypeText,
+		"text":  text,
+		"marks": []map[string]interface{}{{"type": markType}},
+	})
+	if after != "" {
+		nodes = append(nodes, inlineNodes(after)...)
+	}
+	return nodes
+}
+
+// stripInlineMarks renders a line's plain text without Markdown emphasis,
+// for contexts (headings, list items) where this package only stores a
+// single unmarked text run today.
+func stripInlineMarks(line string) string {
+	line = codeSpanPattern.ReplaceAllString(line, "$1")
+	line = boldPattern.ReplaceAllString(line, "$1")
+	line = italicPattern.ReplaceAllString(line, "$1")
+	line = linkPattern.ReplaceAllString(line, "$1")
+	return line
+}
@@ -0,0 +1,104 @@
+package adf
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFromMarkdownPlainTextWrapsInSingleParagraph(t *testing.T) {
+	doc, err := FromMarkdown("just a sentence")
+	if err != nil {
+		t.Fatalf("FromMarkdown returned error: %v", err)
+	}
+	want := Doc().Paragraph("just a sentence").Build()
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("FromMarkdown(plain text) = %#v, want %#v", doc, want)
+	}
+}
+
+func TestFromMarkdownHeadingAndLists(t *testing.T) {
+	source := "# Title\n\n- one\n- two\n\n1. first\n2. second\n"
+	doc, err := FromMarkdown(source)
+	if err != nil {
+		t.Fatalf("FromMarkdown returned error: %v", err)
+	}
+	want := Doc().
+		Heading(1, "Title").
+		BulletList("one", "two").
+		OrderedList("first", "second").
+		Build()
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("FromMarkdown(heading+lists) = %#v, want %#v", doc, want)
+	}
+}
+
+func TestFromMarkdownCodeFence(t *testing.T) {
+	doc, err := FromMarkdown("```go\nfmt.Println(\"hi\")\n```")
+	if err != nil {
+		t.Fatalf("FromMarkdown returned error: %v", err)
+	}
+	want := Doc().CodeBlock("go", `fmt.Println("hi")`).Build()
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("FromMarkdown(code fence) = %#v, want %#v", doc, want)
+	}
+}
+
+func TestFromMarkdownInlineMarks(t *testing.T) {
+	doc, err := FromMarkdown("**bold** and *italic* and `code`")
+	if err != nil {
+		t.Fatalf("FromMarkdown returned error: %v", err)
+	}
+	if err := Validate(doc); err != nil {
+		t.Fatalf("Validate(doc) = %v, want nil", err)
+	}
+}
+
+func TestFromMarkdownLinkAndImage(t *testing.T) {
+	linkDoc, err := FromMarkdown("see [docs](https://example.com/docs)")
+	if err != nil {
+		t.Fatalf("FromMarkdown returned error: %v", err)
+	}
+	if err := Validate(linkDoc); err != nil {
+		t.Fatalf("Validate(link doc) = %v, want nil", err)
+	}
+
+	imageDoc, err := FromMarkdown("![alt text](https://example.com/pic.png)")
+	if err != nil {
+		t.Fatalf("FromMarkdown returned error: %v", err)
+	}
+	if err := Validate(imageDoc); err != nil {
+		t.Fatalf("Validate(image doc) = %v, want nil", err)
+	}
+}
+
+func TestFromMarkdownInlineImageWithinText(t *testing.T) {
+	doc, err := FromMarkdown("see this ![alt](http://x.com/p.png) inline")
+	if err != nil {
+		t.Fatalf("FromMarkdown returned error: %v", err)
+	}
+	if err := Validate(doc); err != nil {
+		t.Fatalf("Validate(doc) = %v, want nil", err)
+	}
+
+	want := map[string]interface{}{
+		"version": docVersion,
+		"type":    TypeDoc,
+		"content": []map[string]interface{}{
+			paragraphNode(
+				textNode("see this "),
+				map[string]interface{}{
+					"type": TypeMedia,
+					"attrs": map[string]interface{}{
+						"type": "external",
+						"url":  "http://x.com/p.png",
+						"alt":  "alt",
+					},
+				},
+				textNode(" inline"),
+			),
+		},
+	}
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("FromMarkdown(inline image) = %#v, want %#v", doc, want)
+	}
+}
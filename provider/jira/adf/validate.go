@@ -0,0 +1,96 @@
+package adf
+
+import "fmt"
+
+// knownTypes are the node types Validate recognizes, covering both what
+// this package's builder/converter emit and what a hand-authored ADF
+// document may legally contain.
+var knownTypes = map[string]bool{
+	TypeDoc:         true,
+	TypeParagraph:   true,
+	TypeText:        true,
+	TypeHeading:     true,
+	TypeCodeBlock:   true,
+	TypeBulletList:  true,
+	TypeOrderedList: true,
+	TypeListItem:    true,
+	TypeBlockquote:  true,
+	TypeTable:       true,
+	TypeTableRow:    true,
+	TypeTableHeader: true,
+	TypeTableCell:   true,
+	TypeHardBreak:   true,
+	TypeMediaSingle: true,
+	TypeMedia:       true,
+	"rule":          true,
+	"panel":         true,
+}
+
+// Validate walks an ADF document tree (as decoded into
+// map[string]interface{}, e.g. from Payload.Description) checking that the
+// root is a versioned "doc" node, every node has a known type, and every
+// text leaf carries non-empty text.
+func Validate(doc map[string]interface{}) error {
+	if doc["type"] != TypeDoc {
+		return fmt.Errorf(`adf: root node must have type %q`, TypeDoc)
+	}
+	if _, ok := doc["version"]; !ok {
+		return fmt.Errorf("adf: root node missing version")
+	}
+	return validateNode(doc)
+}
+
+func validateNode(node map[string]interface{}) error {
+	nodeType, ok := node["type"].(string)
+	if !ok || nodeType == "" {
+		return fmt.Errorf("adf: node missing type")
+	}
+	if !knownTypes[nodeType] {
+		return fmt.Errorf("adf: unknown node type %q", nodeType)
+	}
+
+	if nodeType == TypeText {
+		text, ok := node["text"].(string)
+		if !ok || text == "" {
+			return fmt.Errorf("adf: text node must carry non-empty text")
+		}
+	}
+
+	content, ok := node["content"]
+	if !ok {
+		return nil
+	}
+	nodes, ok := toNodeSlice(content)
+	if !ok {
+		return fmt.Errorf("adf: %q content must be an array of nodes", nodeType)
+	}
+	for _, child := range nodes {
+		if err := validateNode(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toNodeSlice normalizes the two shapes content can arrive in: already
+// decoded as []map[string]interface{} (e.g. from this package's own
+// builder) or as []interface{} of map[string]interface{} (e.g. from
+// json.Unmarshal into a generic map[string]interface{}).
+func toNodeSlice(content interface{}) ([]map[string]interface{}, bool) {
+	switch typed := content.(type) {
+	case []map[string]interface{}:
+		return typed, true
+	case []interface{}:
+		nodes := make([]map[string]interface{}, 0, len(typed))
+		for _, item := range typed {
+			node, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			nodes = append(nodes, node)
+		}
+		return nodes, true
+	default:
+		return nil, false
+	}
+}
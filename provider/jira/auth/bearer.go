@@ -0,0 +1,19 @@
+package auth
+
+import "context"
+
+// BearerToken is a static bearer token credential, e.g. a long-lived
+// Atlassian Connect JWT-exchanged token.
+type BearerToken struct {
+	Token string
+}
+
+// NewBearerToken wraps a static token as a Credential.
+func NewBearerToken(token string) *BearerToken {
+	return &BearerToken{Token: token}
+}
+
+// Header returns the token formatted as a Bearer Authorization header.
+func (b *BearerToken) Header(_ context.Context) (string, error) {
+	return "Bearer " + b.Token, nil
+}
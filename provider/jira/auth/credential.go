@@ -0,0 +1,13 @@
+// Package auth provides the credential types the JIRA provider uses to
+// authenticate outbound requests, decoupling Client from any single
+// authentication scheme.
+package auth
+
+import "context"
+
+// Credential produces the value of the HTTP Authorization header to send
+// with a JIRA API request. Implementations may refresh or rotate the
+// underlying secret as needed before returning.
+type Credential interface {
+	Header(ctx context.Context) (string, error)
+}
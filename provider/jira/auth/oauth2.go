@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// tokenRefreshURL is Atlassian's OAuth2 3LO token endpoint, used both for
+// the initial code exchange (performed by the caller, out of scope here)
+// and for refreshing an expired access token.
+const tokenRefreshURL = "https://auth.atlassian.com/oauth/token"
+
+// refreshSkew is how far ahead of the real expiry OAuth2ThreeLegged
+// refreshes, so a request built just before expiry doesn't race the
+// server's clock.
+const refreshSkew = 60 * time.Second
+
+// Token is the access/refresh token pair OAuth2ThreeLegged persists
+// through a CredentialStore between requests.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	Expiry       time.Time
+}
+
+// expired reports whether the token is within refreshSkew of expiring.
+func (t Token) expired() bool {
+	return time.Now().Add(refreshSkew).After(t.Expiry)
+}
+
+// CredentialStore persists the OAuth2 token across requests (and, for a
+// durable implementation, process restarts) so every notifier Send does
+// not need to re-run the refresh flow.
+type CredentialStore interface {
+	Load(ctx context.Context) (Token, error)
+	Save(ctx context.Context, token Token) error
+}
+
+// InMemoryCredentialStore is the default CredentialStore: it keeps the
+// current token in memory only, so a refreshed token does not survive a
+// process restart.
+type InMemoryCredentialStore struct {
+	mu    sync.Mutex
+	token Token
+}
+
+// NewInMemoryCredentialStore seeds a store with the token obtained from the
+// initial OAuth2 code exchange.
+func NewInMemoryCredentialStore(initial Token) *InMemoryCredentialStore {
+	return &InMemoryCredentialStore{token: initial}
+}
+
+// Load returns the current token.
+func (s *InMemoryCredentialStore) Load(_ context.Context) (Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token, nil
+}
+
+// Save replaces the current token.
+func (s *InMemoryCredentialStore) Save(_ context.Context, token Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+	return nil
+}
+
+// OAuth2ThreeLegged is a Credential backed by Atlassian's OAuth2 3LO flow.
+// Access tokens expire hourly; Header transparently refreshes via
+// RefreshToken when the cached access token is within refreshSkew of
+// expiring, persisting the result through Store.
+type OAuth2ThreeLegged struct {
+	ClientID     string
+	ClientSecret string
+	HTTPClient   *http.Client
+	Store        CredentialStore
+
+	// refreshURL defaults to tokenRefreshURL (the package const) and is
+	// only ever overridden by tests, against an httptest.Server.
+	refreshURL string
+
+	mu sync.Mutex
+}
+
+// NewOAuth2ThreeLegged builds an OAuth2ThreeLegged credential. store must
+// already be seeded with the token from the initial authorization code
+// exchange; use NewInMemoryCredentialStore for a process-local default.
+func NewOAuth2ThreeLegged(clientID, clientSecret string, httpClient *http.Client, store CredentialStore) *OAuth2ThreeLegged {
+	return &OAuth2ThreeLegged{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		HTTPClient:   httpClient,
+		Store:        store,
+		refreshURL:   tokenRefreshURL,
+	}
+}
+
+// Header returns the current access token as a Bearer Authorization
+// header, refreshing it first if it is within refreshSkew of expiring.
+func (o *OAuth2ThreeLegged) Header(ctx context.Context) (string, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	token, err := o.Store.Load(ctx)
+	if err != nil {
+		return "", fmt.Errorf("auth: failed to load oauth2 token: %w", err)
+	}
+
+	if token.expired() {
+		refreshed, err := o.refresh(ctx, token.RefreshToken)
+		if err != nil {
+			return "", fmt.Errorf("auth: failed to refresh oauth2 token: %w", err)
+		}
+		if err := o.Store.Save(ctx, refreshed); err != nil {
+			return "", fmt.Errorf("auth: failed to persist refreshed oauth2 token: %w", err)
+		}
+		token = refreshed
+	}
+
+	return "Bearer " + token.AccessToken, nil
+}
+
+func (o *OAuth2ThreeLegged) refresh(ctx context.Context, refreshToken string) (Token, error) {
+	body, err := json.Marshal(map[string]string{
+		"grant_type":    "refresh_token",
+		"client_id":     o.ClientID,
+		"client_secret": o.ClientSecret,
+		"refresh_token": refreshToken,
+	})
+	if err != nil {
+		return Token{}, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, o.refreshURL, bytes.NewReader(body))
+	if err != nil {
+		return Token{}, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := o.HTTPClient.Do(request)
+	if err != nil {
+		return Token{}, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusBadRequest {
+		return Token{}, fmt.Errorf("oauth2 token refresh returned status %d", response.StatusCode)
+	}
+
+	var payload struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&payload); err != nil {
+		return Token{}, err
+	}
+
+	next := Token{
+		AccessToken:  payload.AccessToken,
+		RefreshToken: payload.RefreshToken,
+		Expiry:       time.Now().Add(time.Duration(payload.ExpiresIn) * time.Second),
+	}
+	if next.RefreshToken == "" {
+		next.RefreshToken = refreshToken
+	}
+	return next, nil
+}
@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func refreshServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, func()) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	return server, server.Close
+}
+
+func TestOAuth2ThreeLeggedHeaderRefreshesExpiredToken(t *testing.T) {
+	var refreshCount int32
+	server, closeServer := refreshServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&refreshCount, 1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "fresh-access-token",
+			"refresh_token": "fresh-refresh-token",
+			"expires_in":    3600,
+		})
+	})
+	defer closeServer()
+
+	store := NewInMemoryCredentialStore(Token{
+		AccessToken:  "stale-access-token",
+		RefreshToken: "initial-refresh-token",
+		Expiry:       time.Now().Add(-time.Minute),
+	})
+	cred := NewOAuth2ThreeLegged("client-id", "client-secret", server.Client(), store)
+	cred.refreshURL = server.URL
+
+	header, err := cred.Header(context.Background())
+	if err != nil {
+		t.Fatalf("Header returned error: %v", err)
+	}
+	if header != "Bearer fresh-access-token" {
+		t.Errorf("Header() = %q, want %q", header, "Bearer fresh-access-token")
+	}
+	if got := atomic.LoadInt32(&refreshCount); got != 1 {
+		t.Errorf("refresh endpoint called %d times, want 1", got)
+	}
+
+	saved, _ := store.Load(context.Background())
+	if saved.RefreshToken != "fresh-refresh-token" {
+		t.Errorf("stored refresh token = %q, want the rotated %q", saved.RefreshToken, "fresh-refresh-token")
+	}
+}
+
+func TestOAuth2ThreeLeggedHeaderFallsBackWhenRefreshTokenNotRotated(t *testing.T) {
+	server, closeServer := refreshServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "fresh-access-token",
+			"expires_in":   3600,
+		})
+	})
+	defer closeServer()
+
+	store := NewInMemoryCredentialStore(Token{
+		AccessToken:  "stale-access-token",
+		RefreshToken: "initial-refresh-token",
+		Expiry:       time.Now().Add(-time.Minute),
+	})
+	cred := NewOAuth2ThreeLegged("client-id", "client-secret", server.Client(), store)
+	cred.refreshURL = server.URL
+
+	if _, err := cred.Header(context.Background()); err != nil {
+		t.Fatalf("Header returned error: %v", err)
+	}
+
+	saved, _ := store.Load(context.Background())
+	if saved.RefreshToken != "initial-refresh-token" {
+		t.Errorf("stored refresh token = %q, want it to keep the un-rotated %q", saved.RefreshToken, "initial-refresh-token")
+	}
+}
+
+func TestOAuth2ThreeLeggedHeaderSerializesConcurrentRefreshes(t *testing.T) {
+	var refreshCount int32
+	server, closeServer := refreshServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&refreshCount, 1)
+		time.Sleep(10 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "fresh-access-token",
+			"refresh_token": "fresh-refresh-token",
+			"expires_in":    3600,
+		})
+	})
+	defer closeServer()
+
+	store := NewInMemoryCredentialStore(Token{
+		AccessToken:  "stale-access-token",
+		RefreshToken: "initial-refresh-token",
+		Expiry:       time.Now().Add(-time.Minute),
+	})
+	cred := NewOAuth2ThreeLegged("client-id", "client-secret", server.Client(), store)
+	cred.refreshURL = server.URL
+
+	const callers = 10
+	var wg sync.WaitGroup
+	wg.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+			if _, err := cred.Header(context.Background()); err != nil {
+				t.Errorf("Header returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&refreshCount); got != 1 {
+		t.Errorf("refresh endpoint called %d times across %d concurrent callers, want exactly 1", got, callers)
+	}
+}
@@ -0,0 +1,60 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/deepsourcelabs/hermes/domain"
+	"github.com/deepsourcelabs/hermes/provider/jira/auth"
+)
+
+// CreateIssueResponse is the subset of POST /rest/api/3/issue's response
+// CreateIssueAuthenticated callers need.
+type CreateIssueResponse struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Self string `json:"self"`
+}
+
+// CreateIssueAuthenticated files a new issue the same way Client.CreateIssue
+// does, but through cred and doJSON instead of request.BearerToken. A bare
+// bearer token string has no way to represent a Basic or OAuth2 3LO secret,
+// so those notifiers need issue creation to go through the same Credential
+// abstraction as the rest of this package instead of request.BearerToken.
+func (c *Client) CreateIssueAuthenticated(ctx context.Context, request *CreateIssueRequest, cred auth.Credential) (*CreateIssueResponse, domain.IError) {
+	fieldsJSON, err := json.Marshal(request.Fields)
+	if err != nil {
+		return nil, errFailedAPICall(err.Error())
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(fieldsJSON, &fields); err != nil {
+		return nil, errFailedAPICall(err.Error())
+	}
+	for key, value := range request.CustomFields {
+		fields[key] = value
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"fields": fields})
+	if err != nil {
+		return nil, errFailedAPICall(err.Error())
+	}
+
+	endpoint := fmt.Sprintf("https://api.atlassian.com/ex/jira/%s/rest/api/3/issue", request.CloudID)
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, errFailedAPICall(err.Error())
+	}
+	if err := setAuthHeader(ctx, httpRequest, cred); err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	response := new(CreateIssueResponse)
+	if err := c.doJSON(httpRequest, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
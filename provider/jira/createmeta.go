@@ -0,0 +1,227 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/deepsourcelabs/hermes/domain"
+	"github.com/deepsourcelabs/hermes/provider/jira/auth"
+)
+
+// FieldSchema describes the JIRA type of a single createmeta field, enough
+// to decide how a user-supplied value should be coerced before it is sent
+// back as part of a CreateIssueRequest.
+type FieldSchema struct {
+	Type     string `json:"type"`
+	Items    string `json:"items,omitempty"`
+	Custom   string `json:"custom,omitempty"`
+	CustomID int    `json:"customId,omitempty"`
+}
+
+// FieldMeta is a single entry from createmeta's "fields" map: the schema
+// for one field on one project/issue-type combination, plus whether JIRA
+// requires it and what options it allows.
+type FieldMeta struct {
+	Key           string        `json:"key"`
+	Name          string        `json:"name"`
+	Required      bool          `json:"required"`
+	Schema        FieldSchema   `json:"schema"`
+	AllowedValues []interface{} `json:"allowedValues,omitempty"`
+}
+
+// CreateMetaResponse is the per-issue-type slice of createmeta that
+// GetCreateMeta resolves down to: every field JIRA will accept when
+// creating an issue of that type in that project, keyed by field id.
+type CreateMetaResponse struct {
+	ProjectKey  string               `json:"project_key"`
+	IssueTypeID string               `json:"issue_type_id"`
+	Fields      map[string]FieldMeta `json:"fields"`
+}
+
+// createMetaCache memoizes GetCreateMeta responses per (cloudID, project,
+// issueType): createmeta field schemas change rarely and every Send would
+// otherwise pay for a full /createmeta fetch on every issue filed.
+type createMetaCache struct {
+	mu    sync.RWMutex
+	byKey map[string]*CreateMetaResponse
+}
+
+var globalCreateMetaCache = &createMetaCache{byKey: map[string]*CreateMetaResponse{}}
+
+func createMetaCacheKey(cloudID, projectKey, issueTypeID string) string {
+	return cloudID + "/" + projectKey + "/" + issueTypeID
+}
+
+// resolveIssueTypeID looks up the numeric issue type id GetCreateMeta's
+// issuetypeIds filter expects, since Opts.IssueType (like
+// CreateIssueRequest.Fields.IssueType.Name) is configured by name. Unlike
+// the legacy bearer-token-only GetIssueTypes (used by GetOptValues, which
+// only ever has a plain connection-test token to work with), this goes
+// through cred and doJSON the same way GetCreateMeta does, so it
+// authenticates correctly for Basic and OAuth2 3LO secrets too.
+func (c *Client) resolveIssueTypeID(ctx context.Context, cloudID, issueTypeName string, cred auth.Credential) (string, domain.IError) {
+	endpoint := fmt.Sprintf("https://api.atlassian.com/ex/jira/%s/rest/api/3/issuetype", cloudID)
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", errFailedAPICall(err.Error())
+	}
+	if err := setAuthHeader(ctx, httpRequest, cred); err != nil {
+		return "", err
+	}
+	httpRequest.Header.Set("Accept", "application/json")
+
+	var issueTypes []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := c.doJSON(httpRequest, &issueTypes); err != nil {
+		return "", err
+	}
+	for _, issueType := range issueTypes {
+		if issueType.Name == issueTypeName {
+			return issueType.ID, nil
+		}
+	}
+	return "", errFailedOptsValidation(fmt.Sprintf("issue_type %q not found for cloud_id %q", issueTypeName, cloudID))
+}
+
+// GetCreateMeta fetches and caches the createmeta field schema for a single
+// project/issue-type pair via
+// /rest/api/3/issue/createmeta?expand=projects.issuetypes.fields. It
+// returns an error, and caches nothing, if the response doesn't actually
+// contain that project/issueType combination, so a transient lookup
+// failure can't permanently poison globalCreateMetaCache for that key.
+func (c *Client) GetCreateMeta(ctx context.Context, cloudID, projectKey, issueTypeID string, cred auth.Credential) (*CreateMetaResponse, domain.IError) {
+	cacheKey := createMetaCacheKey(cloudID, projectKey, issueTypeID)
+
+	globalCreateMetaCache.mu.RLock()
+	cached, ok := globalCreateMetaCache.byKey[cacheKey]
+	globalCreateMetaCache.mu.RUnlock()
+	if ok {
+		return cached, nil
+	}
+
+	endpoint := fmt.Sprintf(
+		"https://api.atlassian.com/ex/jira/%s/rest/api/3/issue/createmeta?projectKeys=%s&issuetypeIds=%s&expand=projects.issuetypes.fields",
+		cloudID, projectKey, issueTypeID,
+	)
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, errFailedAPICall(err.Error())
+	}
+	if err := setAuthHeader(ctx, httpRequest, cred); err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Accept", "application/json")
+
+	var raw struct {
+		Projects []struct {
+			Key        string `json:"key"`
+			IssueTypes []struct {
+				ID     string               `json:"id"`
+				Fields map[string]FieldMeta `json:"fields"`
+			} `json:"issuetypes"`
+		} `json:"projects"`
+	}
+	if err := c.doJSON(httpRequest, &raw); err != nil {
+		return nil, err
+	}
+
+	response := &CreateMetaResponse{ProjectKey: projectKey, IssueTypeID: issueTypeID, Fields: map[string]FieldMeta{}}
+	found := false
+	for _, project := range raw.Projects {
+		if project.Key != projectKey {
+			continue
+		}
+		for _, issueType := range project.IssueTypes {
+			if issueType.ID != issueTypeID {
+				continue
+			}
+			found = true
+			for key, meta := range issueType.Fields {
+				meta.Key = key
+				response.Fields[key] = meta
+			}
+		}
+	}
+	if !found {
+		return nil, errFailedOptsValidation(fmt.Sprintf("createmeta has no project %q / issue type %q for cloud_id %q", projectKey, issueTypeID, cloudID))
+	}
+
+	globalCreateMetaCache.mu.Lock()
+	globalCreateMetaCache.byKey[cacheKey] = response
+	globalCreateMetaCache.mu.Unlock()
+
+	return response, nil
+}
+
+// builtinFields are the createmeta field keys CreateIssueRequest.Fields
+// already supplies outside of opts.Fields (summary via Payload.Summary,
+// project/issuetype via Opts, reporter implicitly defaulted by JIRA to the
+// authenticated user), so CoerceFieldValues must not demand them again.
+var builtinFields = map[string]bool{
+	"summary":   true,
+	"project":   true,
+	"issuetype": true,
+	"reporter":  true,
+}
+
+// CoerceFieldValues validates opts.Fields against a createmeta schema,
+// coercing user-supplied scalars into the shapes JIRA's REST API expects
+// (e.g. a bare string for a user/component field becomes {"name": ...}),
+// and reports any field that is missing but required.
+func CoerceFieldValues(meta *CreateMetaResponse, fields map[string]interface{}) (map[string]interface{}, domain.IError) {
+	coerced := map[string]interface{}{}
+
+	for key, fieldMeta := range meta.Fields {
+		if fieldMeta.Required && !builtinFields[key] {
+			if _, ok := fields[key]; !ok {
+				return nil, errFailedOptsValidation(fmt.Sprintf("field %q (%s) is required by createmeta but missing from opts.fields", key, fieldMeta.Name))
+			}
+		}
+	}
+
+	for key, rawValue := range fields {
+		fieldMeta, known := meta.Fields[key]
+		if !known {
+			return nil, errFailedOptsValidation(fmt.Sprintf("field %q is not a valid field for this project/issue type", key))
+		}
+		coerced[key] = coerceFieldValue(fieldMeta.Schema, rawValue)
+	}
+
+	return coerced, nil
+}
+
+// coerceFieldValue applies the small set of shape conversions createmeta
+// fields commonly need: a bare name/id for single-value reference fields,
+// and an array of those for multi-value ones.
+func coerceFieldValue(schema FieldSchema, rawValue interface{}) interface{} {
+	if schema.Type == "array" {
+		items, ok := rawValue.([]interface{})
+		if !ok {
+			items = []interface{}{rawValue}
+		}
+		coercedItems := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			coercedItems = append(coercedItems, coerceScalar(schema.Items, item))
+		}
+		return coercedItems
+	}
+	return coerceScalar(schema.Type, rawValue)
+}
+
+func coerceScalar(schemaType string, rawValue interface{}) interface{} {
+	switch schemaType {
+	case "user", "component":
+		if name, ok := rawValue.(string); ok {
+			return map[string]interface{}{"name": name}
+		}
+	case "option", "priority":
+		if id, ok := rawValue.(string); ok {
+			return map[string]interface{}{"id": id}
+		}
+	}
+	return rawValue
+}
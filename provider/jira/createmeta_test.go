@@ -0,0 +1,75 @@
+package jira
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCoerceFieldValuesSkipsBuiltinRequiredFields(t *testing.T) {
+	meta := &CreateMetaResponse{
+		ProjectKey:  "OPS",
+		IssueTypeID: "10001",
+		Fields: map[string]FieldMeta{
+			"summary":   {Key: "summary", Name: "Summary", Required: true, Schema: FieldSchema{Type: "string"}},
+			"project":   {Key: "project", Name: "Project", Required: true, Schema: FieldSchema{Type: "project"}},
+			"issuetype": {Key: "issuetype", Name: "Issue Type", Required: true, Schema: FieldSchema{Type: "issuetype"}},
+			"reporter":  {Key: "reporter", Name: "Reporter", Required: true, Schema: FieldSchema{Type: "user"}},
+			"labels":    {Key: "labels", Name: "Labels", Required: false, Schema: FieldSchema{Type: "array", Items: "string"}},
+		},
+	}
+
+	coerced, err := CoerceFieldValues(meta, map[string]interface{}{"labels": "urgent"})
+	if err != nil {
+		t.Fatalf("CoerceFieldValues returned error: %v", err)
+	}
+	want := map[string]interface{}{"labels": []interface{}{"urgent"}}
+	if !reflect.DeepEqual(coerced, want) {
+		t.Errorf("CoerceFieldValues = %#v, want %#v", coerced, want)
+	}
+}
+
+func TestCoerceFieldValuesRejectsMissingNonBuiltinRequiredField(t *testing.T) {
+	meta := &CreateMetaResponse{
+		Fields: map[string]FieldMeta{
+			"customfield_10050": {Key: "customfield_10050", Name: "Severity", Required: true, Schema: FieldSchema{Type: "option"}},
+		},
+	}
+
+	if _, err := CoerceFieldValues(meta, map[string]interface{}{}); err == nil {
+		t.Fatal("CoerceFieldValues returned nil error, want a missing-required-field error")
+	}
+}
+
+func TestCoerceFieldValuesRejectsUnknownField(t *testing.T) {
+	meta := &CreateMetaResponse{Fields: map[string]FieldMeta{}}
+
+	if _, err := CoerceFieldValues(meta, map[string]interface{}{"customfield_99999": "x"}); err == nil {
+		t.Fatal("CoerceFieldValues returned nil error, want an unknown-field error")
+	}
+}
+
+func TestCoerceFieldValueShapes(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema FieldSchema
+		in     interface{}
+		want   interface{}
+	}{
+		{"user scalar", FieldSchema{Type: "user"}, "jdoe", map[string]interface{}{"name": "jdoe"}},
+		{"component scalar", FieldSchema{Type: "component"}, "backend", map[string]interface{}{"name": "backend"}},
+		{"option scalar", FieldSchema{Type: "option"}, "High", map[string]interface{}{"id": "High"}},
+		{"array of users", FieldSchema{Type: "array", Items: "user"}, []interface{}{"a", "b"}, []interface{}{
+			map[string]interface{}{"name": "a"}, map[string]interface{}{"name": "b"},
+		}},
+		{"bare value wrapped into array", FieldSchema{Type: "array", Items: "string"}, "solo", []interface{}{"solo"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := coerceFieldValue(tc.schema, tc.in)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("coerceFieldValue(%+v, %v) = %#v, want %#v", tc.schema, tc.in, got, tc.want)
+			}
+		})
+	}
+}
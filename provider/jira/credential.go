@@ -0,0 +1,91 @@
+package jira
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/deepsourcelabs/hermes/domain"
+	"github.com/deepsourcelabs/hermes/provider/jira/auth"
+)
+
+// Secret type discriminators. NotifierSecret.Type selects which of these
+// resolveCredential builds; an empty Type defaults to SecretTypeBearer for
+// backwards compatibility with existing bearer-token notifiers.
+const (
+	SecretTypeBearer = "bearer"
+	SecretTypeBasic  = "basic"
+	SecretTypeOAuth2 = "oauth2_3lo"
+)
+
+// oauth2Credentials caches one *auth.OAuth2ThreeLegged per OAuth2 notifier
+// (keyed on client id + refresh token), not just the CredentialStore behind
+// it, so its mutex actually serializes concurrent Header() calls for the
+// same secret: two alerts landing at once must not both see an expiring
+// token and race to redeem the same refresh_token against Atlassian, which
+// may have already rotated it after the first use.
+var oauth2Credentials = struct {
+	mu    sync.Mutex
+	byKey map[string]*auth.OAuth2ThreeLegged
+}{byKey: map[string]*auth.OAuth2ThreeLegged{}}
+
+func oauth2Credential(key string, clientID, clientSecret string, httpClient *http.Client, initial auth.Token) *auth.OAuth2ThreeLegged {
+	oauth2Credentials.mu.Lock()
+	defer oauth2Credentials.mu.Unlock()
+	if cred, ok := oauth2Credentials.byKey[key]; ok {
+		return cred
+	}
+	store := auth.NewInMemoryCredentialStore(initial)
+	cred := auth.NewOAuth2ThreeLegged(clientID, clientSecret, httpClient, store)
+	oauth2Credentials.byKey[key] = cred
+	return cred
+}
+
+// validateSecret checks that secret carries the fields its Type actually
+// needs, so a misconfigured notifier fails with a clear config-time error
+// instead of resolveCredential silently building a malformed header (e.g.
+// a Basic secret with no Email base64-encoding to ":<token>") that only
+// surfaces as an opaque 401 from Atlassian.
+func validateSecret(secret *domain.NotifierSecret) domain.IError {
+	switch secret.Type {
+	case "", SecretTypeBearer:
+		if secret.Token == "" {
+			return errFailedOptsValidation("token is required for a bearer secret")
+		}
+	case SecretTypeBasic:
+		if secret.Email == "" || secret.Token == "" {
+			return errFailedOptsValidation("email and token are required for a basic secret")
+		}
+	case SecretTypeOAuth2:
+		if secret.ClientID == "" || secret.ClientSecret == "" || secret.RefreshToken == "" {
+			return errFailedOptsValidation("client_id, client_secret and refresh_token are required for an oauth2_3lo secret")
+		}
+	default:
+		return errFailedOptsValidation("unsupported secret type: " + secret.Type)
+	}
+	return nil
+}
+
+// resolveCredential builds the auth.Credential implementation matching
+// secret.Type, so Client can call cred.Header(ctx) instead of formatting
+// "Bearer <token>" inline. secret.Token is reused as the bearer token for
+// SecretTypeBearer and as the API token for SecretTypeBasic.
+func resolveCredential(secret *domain.NotifierSecret, httpClient *http.Client) (auth.Credential, domain.IError) {
+	if secret == nil {
+		return nil, errFailedOptsValidation("secret not defined in configuration")
+	}
+
+	switch secret.Type {
+	case "", SecretTypeBearer:
+		return auth.NewBearerToken(secret.Token), nil
+	case SecretTypeBasic:
+		return auth.NewBasicAuth(secret.Email, secret.Token), nil
+	case SecretTypeOAuth2:
+		return oauth2Credential(secret.ClientID+"/"+secret.RefreshToken, secret.ClientID, secret.ClientSecret, httpClient, auth.Token{
+			AccessToken:  secret.Token,
+			RefreshToken: secret.RefreshToken,
+			Expiry:       secret.Expiry,
+		}), nil
+	default:
+		return nil, errFailedOptsValidation("unsupported secret type: " + secret.Type)
+	}
+}
@@ -0,0 +1,175 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/deepsourcelabs/hermes/domain"
+	"github.com/deepsourcelabs/hermes/provider/jira/auth"
+	"github.com/segmentio/ksuid"
+)
+
+// Alert lifecycle actions returned from a grouped Send, analogous to the
+// actions the Alertmanager JIRA receiver can take for an incoming alert.
+const (
+	GroupActionCreated   = "created"
+	GroupActionCommented = "commented"
+	GroupActionReopened  = "reopened"
+)
+
+// GroupedActionResponse is returned as domain.Message.ProviderResponse when
+// GroupTemplate is configured, so callers can tell which of the create,
+// comment, or reopen paths was taken for a given alert.
+type GroupedActionResponse struct {
+	Action   string      `json:"action"`
+	IssueKey string      `json:"issue_key"`
+	Response interface{} `json:"response,omitempty"`
+}
+
+// renderGroupKey renders opts.GroupTemplate against the decoded payload,
+// producing the value stored in and searched for on opts.GroupFieldID.
+func renderGroupKey(groupTemplate string, payload *Payload) (string, domain.IError) {
+	tmpl, err := template.New("group_key").Parse(groupTemplate)
+	if err != nil {
+		return "", errFailedOptsValidation(fmt.Sprintf("invalid group_key template: %s", err.Error()))
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, payload); err != nil {
+		return "", errFailedBodyValidation(fmt.Sprintf("failed to render group_key template: %s", err.Error()))
+	}
+	return buf.String(), nil
+}
+
+// sendGrouped implements the Alertmanager-style grouping/dedup/reopen flow:
+// reuse an open issue matching the group key if one exists, reopen a
+// recently resolved one, and otherwise fall back to filing a new issue.
+func (p *jiraSimple) sendGrouped(ctx context.Context, opts *Opts, payload *Payload, cred auth.Credential) (*domain.Message, domain.IError) {
+	groupKey, err := renderGroupKey(opts.GroupTemplate, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	searchResponse, searchErr := p.Client.SearchIssues(ctx, &SearchIssuesRequest{
+		JQL:        buildGroupJQL(opts.ProjectKey, opts.GroupFieldID, groupKey),
+		CloudID:    opts.CloudID,
+		Credential: cred,
+	})
+	if searchErr != nil {
+		return nil, searchErr
+	}
+
+	for _, issue := range searchResponse.Issues {
+		if resolution := issue.Fields.resolutionName(); resolution != "" {
+			if opts.WontFixResolution != "" && resolution == opts.WontFixResolution {
+				continue
+			}
+			if opts.ReopenTransition == "" || !withinReopenWindow(issue.Fields.ResolutionDate.Time, opts.ReopenDuration) {
+				continue
+			}
+
+			transitionResponse, tErr := p.Client.TransitionIssue(ctx, &TransitionIssueRequest{
+				IssueKey:     issue.Key,
+				TransitionID: opts.ReopenTransition,
+				CloudID:      opts.CloudID,
+				Credential:   cred,
+			})
+			if tErr != nil {
+				return nil, tErr
+			}
+
+			return groupedMessage(GroupActionReopened, issue.Key, payload, transitionResponse), nil
+		}
+
+		commentResponse, cErr := p.Client.AddComment(ctx, &AddCommentRequest{
+			IssueKey:   issue.Key,
+			Body:       payload.Description,
+			CloudID:    opts.CloudID,
+			Credential: cred,
+		})
+		if cErr != nil {
+			return nil, cErr
+		}
+
+		return groupedMessage(GroupActionCommented, issue.Key, payload, commentResponse), nil
+	}
+
+	request, reqErr := p.buildCreateIssueRequest(ctx, opts, payload, cred)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	createResponse, createErr := p.Client.CreateIssueAuthenticated(ctx, request, cred)
+	if createErr != nil {
+		return nil, createErr
+	}
+
+	// Stamp the group key onto the new issue so future alerts for the
+	// same group can find it again via buildGroupJQL.
+	if updateErr := p.Client.UpdateIssueFields(ctx, &UpdateIssueFieldsRequest{
+		IssueKey:   createResponse.Key,
+		Fields:     map[string]interface{}{opts.GroupFieldID: groupKey},
+		CloudID:    opts.CloudID,
+		Credential: cred,
+	}); updateErr != nil {
+		return nil, updateErr
+	}
+
+	steps := p.applyLinksAndAttachments(ctx, opts, payload, createResponse.Key, cred)
+
+	return groupedMessage(GroupActionCreated, createResponse.Key, payload, &CreateIssueResult{
+		Response: createResponse,
+		Steps:    steps,
+	}), nil
+}
+
+func groupedMessage(action, issueKey string, payload *Payload, response interface{}) *domain.Message {
+	return &domain.Message{
+		ID:      ksuid.New().String(),
+		Ok:      true,
+		Payload: payload,
+		ProviderResponse: &GroupedActionResponse{
+			Action:   action,
+			IssueKey: issueKey,
+			Response: response,
+		},
+	}
+}
+
+// buildGroupJQL mirrors the search Alertmanager's JIRA receiver performs to
+// find an open issue for a group: same project, matching group key field,
+// and not already in the "Done" status category. groupFieldID is the same
+// raw custom field id stored in Opts.GroupFieldID (e.g. "customfield_10050")
+// that UpdateIssueFields writes to, so the two always refer to the same
+// field; jqlFieldRef turns it into the cf[NNNNN] reference JQL requires for
+// text search against a custom field by id.
+func buildGroupJQL(projectKey, groupFieldID, groupKey string) string {
+	return fmt.Sprintf(
+		`project = %q AND %s ~ %q AND statusCategory != Done`,
+		projectKey, jqlFieldRef(groupFieldID), groupKey,
+	)
+}
+
+// jqlFieldRef renders a createmeta field id as the JQL term that searches
+// it: "cf[NNNNN]" for a custom field id, or the quoted field name as-is for
+// a system field.
+func jqlFieldRef(fieldID string) string {
+	const customFieldPrefix = "customfield_"
+	if strings.HasPrefix(fieldID, customFieldPrefix) {
+		return fmt.Sprintf("cf[%s]", strings.TrimPrefix(fieldID, customFieldPrefix))
+	}
+	return fmt.Sprintf("%q", fieldID)
+}
+
+// withinReopenWindow reports whether resolvedAt is recent enough that the
+// issue should be reopened instead of superseded by a new one.
+func withinReopenWindow(resolvedAt time.Time, reopenDuration time.Duration) bool {
+	if reopenDuration <= 0 {
+		return false
+	}
+	return time.Since(resolvedAt) <= reopenDuration
+}
@@ -3,11 +3,13 @@ package jira
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/deepsourcelabs/hermes/domain"
 	"github.com/deepsourcelabs/hermes/provider"
+	"github.com/deepsourcelabs/hermes/provider/jira/adf"
+	"github.com/deepsourcelabs/hermes/provider/jira/auth"
 	"github.com/mitchellh/mapstructure"
 	"github.com/segmentio/ksuid"
 )
@@ -24,7 +26,7 @@ func NewJIRAProvider(httpClient *http.Client) provider.Provider {
 	}
 }
 
-func (p *jiraSimple) Send(_ context.Context, notifier *domain.Notifier, body []byte) (*domain.Message, domain.IError) {
+func (p *jiraSimple) Send(ctx context.Context, notifier *domain.Notifier, body []byte) (*domain.Message, domain.IError) {
 	// Extract and validate the payload.
 	var payload = new(Payload)
 	if err := payload.Extract(body); err != nil {
@@ -44,6 +46,45 @@ func (p *jiraSimple) Send(_ context.Context, notifier *domain.Notifier, body []b
 		return nil, err
 	}
 
+	cred, credErr := resolveCredential(opts.Secret, p.Client.HTTPClient)
+	if credErr != nil {
+		return nil, credErr
+	}
+
+	// If grouping is configured, try to fold this alert into an existing
+	// open issue instead of always filing a new one.
+	if opts.GroupTemplate != "" {
+		return p.sendGrouped(ctx, opts, payload, cred)
+	}
+
+	request, reqErr := p.buildCreateIssueRequest(ctx, opts, payload, cred)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	response, err := p.Client.CreateIssueAuthenticated(ctx, request, cred)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := p.applyLinksAndAttachments(ctx, opts, payload, response.Key, cred)
+
+	return &domain.Message{
+		ID:      ksuid.New().String(),
+		Ok:      true,
+		Payload: payload,
+		ProviderResponse: &CreateIssueResult{
+			Response: response,
+			Steps:    steps,
+		},
+	}, nil
+}
+
+// buildCreateIssueRequest assembles the CreateIssueRequest both Send and
+// sendGrouped file: the fixed fields from opts/payload, plus, when opts.Fields
+// is set, the createmeta-validated custom fields resolved via
+// resolveIssueTypeID/GetCreateMeta/CoerceFieldValues.
+func (p *jiraSimple) buildCreateIssueRequest(ctx context.Context, opts *Opts, payload *Payload, cred auth.Credential) (*CreateIssueRequest, domain.IError) {
 	request := &CreateIssueRequest{
 		Fields: Fields{
 			Project:     Project{Key: opts.ProjectKey},
@@ -55,88 +96,173 @@ func (p *jiraSimple) Send(_ context.Context, notifier *domain.Notifier, body []b
 		BearerToken: opts.Secret.Token,
 	}
 
-	response, err := p.Client.CreateIssue(request)
-	if err != nil {
-		return nil, err
+	if len(opts.Fields) > 0 {
+		issueTypeID, idErr := p.Client.resolveIssueTypeID(ctx, opts.CloudID, opts.IssueType, cred)
+		if idErr != nil {
+			return nil, idErr
+		}
+		createMeta, metaErr := p.Client.GetCreateMeta(ctx, opts.CloudID, opts.ProjectKey, issueTypeID, cred)
+		if metaErr != nil {
+			return nil, metaErr
+		}
+		customFields, coerceErr := CoerceFieldValues(createMeta, opts.Fields)
+		if coerceErr != nil {
+			return nil, coerceErr
+		}
+		request.CustomFields = customFields
 	}
 
-	return &domain.Message{
-		ID:               ksuid.New().String(),
-		Ok:               true,
-		Payload:          payload,
-		ProviderResponse: response,
-	}, nil
+	return request, nil
 }
 
-func (p *jiraSimple) GetOptValues(_ context.Context, opts *domain.NotifierSecret) (*map[string]interface{}, error) {
+func (p *jiraSimple) GetOptValues(ctx context.Context, opts *domain.NotifierSecret) (*map[string]interface{}, error) {
 	acessibleResourcesRequest := &AccessibleResourcesRequest{
 		BearerToken: opts.Token,
 	}
-	accessibleResourcesResponse, err := p.Client.GetAccessibleResources(acessibleResourcesRequest)
-	if err != nil {
-		fmt.Println("foobar0: ", err)
-		return nil, err
-	}
-	sites := []map[string]string{}
-	siteOptValues := map[string]map[string][]map[string]string{}
-	for _, site := range *accessibleResourcesResponse {
-		sites = append(sites, map[string]string{"id": site.ID, "name": site.Name})
-		issueTypesResponse, err := p.Client.GetIssueTypes(&GetIssueTypesRequest{BearerToken: opts.Token, CloudID: site.ID})
+
+	var sites []map[string]string
+	var cloudIDs []string
+	accessibleResourcesErr := withLegacyRetry(ctx, func() domain.IError {
+		accessibleResourcesResponse, err := p.Client.GetAccessibleResources(ctx, acessibleResourcesRequest)
 		if err != nil {
-			fmt.Println("foobar1: ", err)
-			return nil, err
+			return err
 		}
-		issueTypes := []map[string]string{}
-		for _, it := range *issueTypesResponse {
-			issueTypes = append(issueTypes, map[string]string{
-				"id":   it.ID,
-				"name": it.Name,
-			})
+		sites = nil
+		cloudIDs = nil
+		for _, site := range *accessibleResourcesResponse {
+			sites = append(sites, map[string]string{"id": site.ID, "name": site.Name})
+			cloudIDs = append(cloudIDs, site.ID)
 		}
+		return nil
+	})
+	if accessibleResourcesErr != nil {
+		return nil, accessibleResourcesErr
+	}
 
-		projects, err := p.Client.GetProjects(&GetProjectsRequest{BearerToken: opts.Token, CloudID: site.ID})
-		if err != nil {
-			fmt.Println("foobar2: ", err)
-			return nil, err
+	siteOptValues := map[string]map[string][]map[string]string{}
+	siteFieldOptValues := map[string]map[string]map[string]*CreateMetaResponse{}
+
+	for _, cloudID := range cloudIDs {
+		var issueTypes []map[string]string
+		var issueTypeIDs []string
+		issueTypesErr := withLegacyRetry(ctx, func() domain.IError {
+			issueTypesResponse, err := p.Client.GetIssueTypes(ctx, &GetIssueTypesRequest{BearerToken: opts.Token, CloudID: cloudID})
+			if err != nil {
+				return err
+			}
+			issueTypes = nil
+			issueTypeIDs = nil
+			for _, it := range *issueTypesResponse {
+				issueTypes = append(issueTypes, map[string]string{"id": it.ID, "name": it.Name})
+				issueTypeIDs = append(issueTypeIDs, it.ID)
+			}
+			return nil
+		})
+		if issueTypesErr != nil {
+			return nil, issueTypesErr
 		}
-		projectKeys := []map[string]string{}
-		for _, p := range projects.Values {
-			projectKeys = append(projectKeys, map[string]string{
-				"id":   p.Key,
-				"name": p.Name,
-			})
+
+		var projectKeys []map[string]string
+		var projectKeyIDs []string
+		projectsErr := withLegacyRetry(ctx, func() domain.IError {
+			projects, err := p.Client.GetProjects(ctx, &GetProjectsRequest{BearerToken: opts.Token, CloudID: cloudID})
+			if err != nil {
+				return err
+			}
+			projectKeys = nil
+			projectKeyIDs = nil
+			for _, project := range projects.Values {
+				projectKeys = append(projectKeys, map[string]string{"id": project.Key, "name": project.Name})
+				projectKeyIDs = append(projectKeyIDs, project.Key)
+			}
+			return nil
+		})
+		if projectsErr != nil {
+			return nil, projectsErr
 		}
 
-		siteOptValues[site.ID] = map[string][]map[string]string{
+		siteOptValues[cloudID] = map[string][]map[string]string{
 			"project_key": projectKeys,
 			"issue_type":  issueTypes,
 		}
+
+		fieldOptValues := map[string]map[string]*CreateMetaResponse{}
+		for _, projectKey := range projectKeyIDs {
+			fieldOptValues[projectKey] = map[string]*CreateMetaResponse{}
+			for _, issueTypeID := range issueTypeIDs {
+				createMeta, err := p.Client.GetCreateMeta(ctx, cloudID, projectKey, issueTypeID, auth.NewBearerToken(opts.Token))
+				if err != nil {
+					continue
+				}
+				fieldOptValues[projectKey][issueTypeID] = createMeta
+			}
+		}
+		siteFieldOptValues[cloudID] = fieldOptValues
 	}
 
 	return &map[string]interface{}{
 		"cloud_id": sites,
 		"_rel": map[string]interface{}{
 			"cloud_id": siteOptValues,
+			"fields":   siteFieldOptValues,
 		},
 	}, nil
 }
 
 // Payload defines the primary content payload for the JIRA provider.
 type Payload struct {
-	Summary     string                 `json:"summary"`
+	Summary string `json:"summary"`
+	// Description holds the issue/comment body as an ADF document
+	// (map[string]interface{}), regardless of how it arrived on the
+	// wire. See Extract.
 	Description map[string]interface{} `json:"description"`
+	// Attachments are uploaded to the issue after it is created; a
+	// failure to upload one does not fail the whole Send.
+	Attachments []Attachment `json:"attachments"`
 }
 
-// Extract unmarshals body to JIRA payload.
+// Extract unmarshals body to a JIRA payload. Description is accepted in
+// three shapes: a raw ADF document object, which is used as-is; or a JSON
+// string, which is run through adf.FromMarkdown and so may be plain text
+// (auto-wrapped in a single paragraph) or Markdown. An absent description
+// key and an explicit JSON null are both left as p.Description == nil, so
+// Validate rejects both the same way.
 func (p *Payload) Extract(body []byte) domain.IError {
-	if err := json.Unmarshal(body, p); err != nil {
+	var raw struct {
+		Summary     string          `json:"summary"`
+		Description json.RawMessage `json:"description"`
+		Attachments []Attachment    `json:"attachments"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
 		return errFailedBodyValidation(err.Error())
 	}
+	p.Summary = raw.Summary
+	p.Attachments = raw.Attachments
+
+	if len(raw.Description) == 0 || string(raw.Description) == "null" {
+		return nil
+	}
+
+	var asString string
+	if err := json.Unmarshal(raw.Description, &asString); err == nil {
+		doc, convErr := adf.FromMarkdown(asString)
+		if convErr != nil {
+			return errFailedBodyValidation(convErr.Error())
+		}
+		p.Description = doc
+		return nil
+	}
+
+	var asDoc map[string]interface{}
+	if err := json.Unmarshal(raw.Description, &asDoc); err != nil {
+		return errFailedBodyValidation("description must be a string or an ADF document object")
+	}
+	p.Description = asDoc
 	return nil
 }
 
-// Validate() validates the payload ensuring all mandatory properties are set.
-// Description should ideally be validated agaings JDF (Jira Document Format)
+// Validate() validates the payload ensuring all mandatory properties are set
+// and, when present, that Description is a well-formed ADF document.
 func (p *Payload) Validate() domain.IError {
 	if p.Summary == "" {
 		return errFailedBodyValidation(
@@ -148,6 +274,9 @@ func (p *Payload) Validate() domain.IError {
 			"generated payload does not contain mandatory param description",
 		)
 	}
+	if err := adf.Validate(p.Description); err != nil {
+		return errFailedBodyValidation(err.Error())
+	}
 	return nil
 }
 
@@ -157,6 +286,38 @@ type Opts struct {
 	ProjectKey string `mapstructure:"project_key"`
 	IssueType  string `mapstructure:"issue_type"`
 	CloudID    string `mapstructure:"cloud_id"`
+
+	// GroupTemplate is a Go text/template rendered against the incoming
+	// payload to derive a stable grouping key, mirroring Alertmanager's
+	// JIRA receiver. When empty, grouping is disabled and every Send
+	// creates a new issue.
+	GroupTemplate string `mapstructure:"group_key"`
+	// GroupFieldID is the JIRA custom field (e.g. "customfield_10050")
+	// that stores the rendered group key on created issues, and is
+	// searched via JQL to find an existing issue to reuse.
+	GroupFieldID string `mapstructure:"group_field_id"`
+	// ReopenTransition is the workflow transition name used to move a
+	// resolved-but-not-Done issue back to an open status when a new
+	// alert arrives for the same group key within ReopenDuration.
+	ReopenTransition string `mapstructure:"reopen_transition"`
+	// ReopenDuration bounds how long after resolution an issue is still
+	// eligible to be reopened rather than superseded by a new issue.
+	ReopenDuration time.Duration `mapstructure:"reopen_duration"`
+	// WontFixResolution names a resolution value that marks an issue as
+	// permanently closed for the group, so it is never reopened or
+	// commented on again even within ReopenDuration.
+	WontFixResolution string `mapstructure:"wont_fix_resolution"`
+
+	// Fields carries arbitrary createmeta-backed fields to set on the
+	// created issue: components, labels, priority, assignee, reporter,
+	// due date, parent (for sub-tasks), and any customfield_NNNNN. Values
+	// are validated and coerced against GetCreateMeta's schema in Send.
+	Fields map[string]interface{} `mapstructure:"fields"`
+
+	// Links are issue links to create against the newly filed issue,
+	// resolved either by key or by the top results of a JQL search. See
+	// applyLinksAndAttachments.
+	Links []LinkSpec `mapstructure:"links"`
 }
 
 func (o *Opts) Extract(c *domain.NotifierConfiguration) domain.IError {
@@ -180,8 +341,15 @@ func (o *Opts) Validate() domain.IError {
 		return errFailedOptsValidation("issue_type or project_key is emtpy")
 	}
 
-	if o.Secret == nil || o.Secret.Token == "" {
+	if o.Secret == nil {
 		return errFailedOptsValidation("secret not defined in configuration")
 	}
+	if err := validateSecret(o.Secret); err != nil {
+		return err
+	}
+
+	if o.GroupTemplate != "" && o.GroupFieldID == "" {
+		return errFailedOptsValidation("group_field_id is required when group_key is set")
+	}
 	return nil
 }
@@ -0,0 +1,228 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+
+	"github.com/deepsourcelabs/hermes/domain"
+	"github.com/deepsourcelabs/hermes/provider/jira/auth"
+)
+
+// defaultJQLLinkLimit bounds how many issues a JQL-based LinkSpec links to
+// when the notifier config does not set Limit explicitly.
+const defaultJQLLinkLimit = 5
+
+// LinkSpec describes one issue link to create after an issue is filed,
+// either against a known key or against the top results of a JQL search.
+type LinkSpec struct {
+	Type        string `mapstructure:"type"`
+	InwardIssue string `mapstructure:"inward_issue"`
+	JQL         string `mapstructure:"jql"`
+	Limit       int    `mapstructure:"limit"`
+}
+
+// Attachment is a file to upload to a created issue, carried inline in the
+// notification payload.
+type Attachment struct {
+	Filename      string `json:"filename"`
+	MimeType      string `json:"mime_type"`
+	ContentBase64 string `json:"content_base64"`
+}
+
+// StepStatus records the outcome of a single post-creation step (a link or
+// an attachment) so a partial failure there is visible without rolling
+// back the issue that was already created.
+type StepStatus struct {
+	Step  string `json:"step"`
+	Ok    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// CreateIssueResult wraps the CreateIssue response together with the
+// status of every link/attachment step attempted afterwards.
+type CreateIssueResult struct {
+	Response interface{}  `json:"response"`
+	Steps    []StepStatus `json:"steps,omitempty"`
+}
+
+// LinkIssuesRequest creates a single issue link via POST
+// /rest/api/3/issueLink.
+type LinkIssuesRequest struct {
+	InwardIssue  string
+	OutwardIssue string
+	LinkType     string
+	CloudID      string
+	Credential   auth.Credential
+}
+
+// LinkIssues links two issues, e.g. marking the newly created issue as
+// "Blocks" an existing one.
+func (c *Client) LinkIssues(ctx context.Context, request *LinkIssuesRequest) domain.IError {
+	endpoint := fmt.Sprintf("https://api.atlassian.com/ex/jira/%s/rest/api/3/issueLink", request.CloudID)
+	body, err := json.Marshal(map[string]interface{}{
+		"type":         map[string]string{"name": request.LinkType},
+		"inwardIssue":  map[string]string{"key": request.InwardIssue},
+		"outwardIssue": map[string]string{"key": request.OutwardIssue},
+	})
+	if err != nil {
+		return errFailedAPICall(err.Error())
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errFailedAPICall(err.Error())
+	}
+	if err := setAuthHeader(ctx, httpRequest, request.Credential); err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	return c.doJSON(httpRequest, nil)
+}
+
+// AddAttachmentRequest uploads a single file to an existing issue via
+// multipart POST /rest/api/3/issue/{key}/attachments.
+type AddAttachmentRequest struct {
+	IssueKey    string
+	Filename    string
+	ContentType string
+	Content     io.Reader
+	CloudID     string
+	Credential  auth.Credential
+}
+
+// AttachmentMeta is one entry of AddAttachment's response: the metadata
+// JIRA assigns the uploaded file.
+type AttachmentMeta struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename"`
+}
+
+// AddAttachment uploads a file to an issue. JIRA requires the
+// X-Atlassian-Token: no-check header on this endpoint to bypass XSRF
+// checks for multipart uploads.
+func (c *Client) AddAttachment(ctx context.Context, request *AddAttachmentRequest) ([]AttachmentMeta, domain.IError) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	partHeader := make(textproto.MIMEHeader)
+	partHeader.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, request.Filename))
+	if request.ContentType != "" {
+		partHeader.Set("Content-Type", request.ContentType)
+	}
+	part, err := writer.CreatePart(partHeader)
+	if err != nil {
+		return nil, errFailedAPICall(err.Error())
+	}
+	if _, err := io.Copy(part, request.Content); err != nil {
+		return nil, errFailedAPICall(err.Error())
+	}
+	if err := writer.Close(); err != nil {
+		return nil, errFailedAPICall(err.Error())
+	}
+
+	endpoint := fmt.Sprintf("https://api.atlassian.com/ex/jira/%s/rest/api/3/issue/%s/attachments", request.CloudID, request.IssueKey)
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body.Bytes()))
+	if err != nil {
+		return nil, errFailedAPICall(err.Error())
+	}
+	if err := setAuthHeader(ctx, httpRequest, request.Credential); err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", writer.FormDataContentType())
+	httpRequest.Header.Set("X-Atlassian-Token", "no-check")
+
+	var response []AttachmentMeta
+	if err := c.doJSON(httpRequest, &response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// applyLinksAndAttachments runs opts.Links and payload.Attachments against
+// a just-created issue. Each step's outcome is recorded independently;
+// a failure here never undoes the issue creation that already succeeded.
+func (p *jiraSimple) applyLinksAndAttachments(ctx context.Context, opts *Opts, payload *Payload, issueKey string, cred auth.Credential) []StepStatus {
+	var steps []StepStatus
+
+	for _, link := range opts.Links {
+		steps = append(steps, p.applyLink(ctx, opts, link, issueKey, cred)...)
+	}
+
+	for _, attachment := range payload.Attachments {
+		steps = append(steps, p.applyAttachment(ctx, opts, attachment, issueKey, cred))
+	}
+
+	return steps
+}
+
+func (p *jiraSimple) applyLink(ctx context.Context, opts *Opts, link LinkSpec, issueKey string, cred auth.Credential) []StepStatus {
+	targets := []string{link.InwardIssue}
+
+	if link.InwardIssue == "" && link.JQL != "" {
+		limit := link.Limit
+		if limit <= 0 {
+			limit = defaultJQLLinkLimit
+		}
+		searchResponse, err := p.Client.SearchIssues(ctx, &SearchIssuesRequest{
+			JQL:        link.JQL,
+			MaxResults: limit,
+			CloudID:    opts.CloudID,
+			Credential: cred,
+		})
+		if err != nil {
+			return []StepStatus{{Step: fmt.Sprintf("link:jql:%s", link.JQL), Ok: false, Error: err.Error()}}
+		}
+		targets = targets[:0]
+		for _, issue := range searchResponse.Issues {
+			targets = append(targets, issue.Key)
+		}
+	}
+
+	statuses := make([]StepStatus, 0, len(targets))
+	for _, target := range targets {
+		if target == "" {
+			continue
+		}
+		step := fmt.Sprintf("link:%s:%s", link.Type, target)
+		if err := p.Client.LinkIssues(ctx, &LinkIssuesRequest{
+			InwardIssue:  target,
+			OutwardIssue: issueKey,
+			LinkType:     link.Type,
+			CloudID:      opts.CloudID,
+			Credential:   cred,
+		}); err != nil {
+			statuses = append(statuses, StepStatus{Step: step, Ok: false, Error: err.Error()})
+			continue
+		}
+		statuses = append(statuses, StepStatus{Step: step, Ok: true})
+	}
+	return statuses
+}
+
+func (p *jiraSimple) applyAttachment(ctx context.Context, opts *Opts, attachment Attachment, issueKey string, cred auth.Credential) StepStatus {
+	step := fmt.Sprintf("attachment:%s", attachment.Filename)
+
+	content, err := base64.StdEncoding.DecodeString(attachment.ContentBase64)
+	if err != nil {
+		return StepStatus{Step: step, Ok: false, Error: err.Error()}
+	}
+
+	if _, apiErr := p.Client.AddAttachment(ctx, &AddAttachmentRequest{
+		IssueKey:    issueKey,
+		Filename:    attachment.Filename,
+		ContentType: attachment.MimeType,
+		Content:     bytes.NewReader(content),
+		CloudID:     opts.CloudID,
+		Credential:  cred,
+	}); apiErr != nil {
+		return StepStatus{Step: step, Ok: false, Error: apiErr.Error()}
+	}
+	return StepStatus{Step: step, Ok: true}
+}
@@ -0,0 +1,31 @@
+package jira
+
+import (
+	"context"
+	"testing"
+)
+
+func TestApplyLinkWithNoTargetSkipsWithoutCallingClient(t *testing.T) {
+	p := &jiraSimple{}
+	opts := &Opts{}
+	link := LinkSpec{Type: "blocks"}
+
+	steps := p.applyLink(context.Background(), opts, link, "OPS-1", nil)
+	if len(steps) != 0 {
+		t.Errorf("applyLink with no inward_issue/jql = %#v, want no steps", steps)
+	}
+}
+
+func TestApplyAttachmentRejectsInvalidBase64WithoutCallingClient(t *testing.T) {
+	p := &jiraSimple{}
+	opts := &Opts{}
+	attachment := Attachment{Filename: "bad.txt", ContentBase64: "not-valid-base64!!"}
+
+	step := p.applyAttachment(context.Background(), opts, attachment, "OPS-1", nil)
+	if step.Ok {
+		t.Errorf("applyAttachment(invalid base64) = %#v, want Ok=false", step)
+	}
+	if step.Error == "" {
+		t.Error("applyAttachment(invalid base64) left Error empty")
+	}
+}
@@ -0,0 +1,193 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/deepsourcelabs/hermes/domain"
+)
+
+// RetryConfig controls the exponential backoff retry behavior shared by
+// every Client method that goes through doJSON.
+type RetryConfig struct {
+	// MaxAttempts is the total number of tries, including the first.
+	MaxAttempts int
+	// InitialBackoff is the delay before the second attempt.
+	InitialBackoff time.Duration
+	// BackoffFactor multiplies the delay after each retriable failure.
+	BackoffFactor float64
+	// MaxBackoff caps the computed delay before jitter is applied.
+	MaxBackoff time.Duration
+	// JitterFraction randomizes the delay by +/- this fraction, to avoid
+	// thundering-herd retries across concurrent notifiers.
+	JitterFraction float64
+}
+
+// defaultRetryConfig matches Atlassian's documented rate-limit guidance:
+// back off starting at 100ms, x1.5 per attempt, capped at 30s, giving up
+// after 6 attempts unless the context deadline is reached first.
+var defaultRetryConfig = RetryConfig{
+	MaxAttempts:    6,
+	InitialBackoff: 100 * time.Millisecond,
+	BackoffFactor:  1.5,
+	MaxBackoff:     30 * time.Second,
+	JitterFraction: 0.2,
+}
+
+func (c *Client) retryConfig() RetryConfig {
+	if c.RetryConfig != nil {
+		return *c.RetryConfig
+	}
+	return defaultRetryConfig
+}
+
+// APIError is a parsed JIRA error response, letting callers distinguish
+// e.g. "project not found" (400) from "rate limited" (429) instead of
+// matching on an opaque error string.
+type APIError struct {
+	StatusCode int
+	Messages   []string
+	Errors     map[string]string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("jira API error (status=%d retriable=%t): messages=%v errors=%v",
+		e.StatusCode, isRetriableStatus(e.StatusCode), e.Messages, e.Errors)
+}
+
+// IsRetriableJIRAError reports whether err represents a retriable upstream
+// JIRA failure (429/502/503/504) as opposed to a non-retriable one (e.g. a
+// 400 for a malformed request). doJSON already retries the former itself;
+// this lets a caller that still received an error after retries were
+// exhausted tell the two apart, since domain.IError carries no status code
+// of its own for errFailedAPICall to attach one to.
+func IsRetriableJIRAError(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.Contains(err.Error(), "retriable=true")
+}
+
+func parseAPIError(response *http.Response) *APIError {
+	apiErr := &APIError{StatusCode: response.StatusCode}
+	var body struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&body); err == nil {
+		apiErr.Messages = body.ErrorMessages
+		apiErr.Errors = body.Errors
+	}
+	return apiErr
+}
+
+func isRetriableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func isRetriableTransportError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return netErr.Timeout() || netErr.Temporary() //nolint:staticcheck // Temporary is deprecated but still the clearest signal here
+	}
+	return false
+}
+
+// parseRetryAfter understands both forms JIRA's Retry-After header can
+// take: delta-seconds ("120") and an HTTP-date. It returns 0 if the header
+// is absent or unparsable, so the caller falls back to its own backoff.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		if delay := time.Until(when); delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
+
+// nextBackoff advances backoff by cfg.BackoffFactor (capped at
+// cfg.MaxBackoff) and returns the jittered delay to actually sleep for.
+func nextBackoff(backoff *time.Duration, cfg RetryConfig) time.Duration {
+	delay := *backoff
+	jitterRange := float64(delay) * cfg.JitterFraction
+	jittered := float64(delay) + (rand.Float64()*2-1)*jitterRange
+	if jittered < 0 {
+		jittered = 0
+	}
+
+	*backoff = time.Duration(float64(*backoff) * cfg.BackoffFactor)
+	if *backoff > cfg.MaxBackoff {
+		*backoff = cfg.MaxBackoff
+	}
+	return time.Duration(jittered)
+}
+
+// waitBackoff sleeps for delay (or until ctx is done, whichever comes
+// first) and reports whether it's worth trying again.
+func waitBackoff(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// looksRetriableLegacyError reports whether err's message suggests a
+// transient JIRA failure (rate limiting or a 5xx). It is a pragmatic,
+// string-matching stand-in for isRetriableStatus for Client methods
+// (CreateIssue, GetAccessibleResources, GetIssueTypes, GetProjects) that
+// predate doJSON, return a domain.IError with no structured status code,
+// and whose implementation lives outside this package.
+func looksRetriableLegacyError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, marker := range []string{"429", "502", "503", "504", "rate limit", "too many requests", "timeout", "temporarily unavailable", "service unavailable"} {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// withLegacyRetry retries fn, a legacy Client call wrapped in a closure so
+// its result can be assigned to an already-typed variable in the caller
+// instead of being returned here, with the same backoff doJSON uses.
+func withLegacyRetry(ctx context.Context, fn func() domain.IError) domain.IError {
+	cfg := defaultRetryConfig
+	backoff := cfg.InitialBackoff
+	var lastErr domain.IError
+	for attempt := 1; attempt <= cfg.MaxAttempts; attempt++ {
+		lastErr = fn()
+		if lastErr == nil {
+			return nil
+		}
+		if attempt == cfg.MaxAttempts || !looksRetriableLegacyError(lastErr) {
+			return lastErr
+		}
+		if !waitBackoff(ctx, nextBackoff(&backoff, cfg)) {
+			return lastErr
+		}
+	}
+	return lastErr
+}
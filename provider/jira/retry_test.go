@@ -0,0 +1,182 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func fastRetryClient() *Client {
+	return &Client{
+		HTTPClient: http.DefaultClient,
+		RetryConfig: &RetryConfig{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			BackoffFactor:  1,
+			MaxBackoff:     5 * time.Millisecond,
+			JitterFraction: 0,
+		},
+	}
+}
+
+func TestDoJSONRetriesRateLimitThenSucceeds(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+
+	var out struct {
+		OK bool `json:"ok"`
+	}
+	if ierr := fastRetryClient().doJSON(request, &out); ierr != nil {
+		t.Fatalf("doJSON returned error: %v", ierr)
+	}
+	if !out.OK {
+		t.Error("doJSON did not decode the successful response")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("server received %d requests, want 2 (one 429, one success)", got)
+	}
+}
+
+func TestDoJSONGivesUpAfterMaxAttempts(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := fastRetryClient()
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+
+	if ierr := client.doJSON(request, nil); ierr == nil {
+		t.Fatal("doJSON returned nil error, want an error after exhausting retries")
+	}
+	if got := atomic.LoadInt32(&attempts); int(got) != client.retryConfig().MaxAttempts {
+		t.Errorf("server received %d requests, want %d (MaxAttempts)", got, client.retryConfig().MaxAttempts)
+	}
+}
+
+func TestDoJSONDoesNotRetryNonRetriableStatus(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+
+	if ierr := fastRetryClient().doJSON(request, nil); ierr == nil {
+		t.Fatal("doJSON returned nil error, want an error for a 400")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("server received %d requests, want 1 (a 400 must not be retried)", got)
+	}
+}
+
+func TestIsRetriableStatus(t *testing.T) {
+	cases := map[int]bool{
+		http.StatusTooManyRequests:     true,
+		http.StatusBadGateway:          true,
+		http.StatusServiceUnavailable:  true,
+		http.StatusGatewayTimeout:      true,
+		http.StatusBadRequest:          false,
+		http.StatusNotFound:            false,
+		http.StatusInternalServerError: false,
+	}
+	for status, want := range cases {
+		if got := isRetriableStatus(status); got != want {
+			t.Errorf("isRetriableStatus(%d) = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if got := parseRetryAfter(""); got != 0 {
+		t.Errorf("parseRetryAfter(%q) = %v, want 0", "", got)
+	}
+	if got := parseRetryAfter("120"); got != 120*time.Second {
+		t.Errorf("parseRetryAfter(%q) = %v, want %v", "120", got, 120*time.Second)
+	}
+	if got := parseRetryAfter("not-a-valid-header"); got != 0 {
+		t.Errorf("parseRetryAfter(%q) = %v, want 0", "not-a-valid-header", got)
+	}
+
+	future := time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(future); got <= 0 || got > 30*time.Second {
+		t.Errorf("parseRetryAfter(%q) = %v, want a positive duration <= 30s", future, got)
+	}
+
+	past := time.Now().Add(-30 * time.Second).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(past); got != 0 {
+		t.Errorf("parseRetryAfter(%q) = %v, want 0 for a past date", past, got)
+	}
+}
+
+func TestNextBackoff(t *testing.T) {
+	cfg := RetryConfig{
+		InitialBackoff: 100 * time.Millisecond,
+		BackoffFactor:  2,
+		MaxBackoff:     1 * time.Second,
+		JitterFraction: 0.5,
+	}
+	backoff := cfg.InitialBackoff
+	for i := 0; i < 10; i++ {
+		delay := nextBackoff(&backoff, cfg)
+		if delay < 0 {
+			t.Fatalf("nextBackoff returned negative delay: %v", delay)
+		}
+		if backoff > cfg.MaxBackoff {
+			t.Fatalf("backoff exceeded MaxBackoff: %v > %v", backoff, cfg.MaxBackoff)
+		}
+	}
+	if backoff != cfg.MaxBackoff {
+		t.Errorf("backoff = %v after 10 doublings, want it capped at MaxBackoff %v", backoff, cfg.MaxBackoff)
+	}
+}
+
+func TestWaitBackoffRespectsContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if waitBackoff(ctx, time.Second) {
+		t.Error("waitBackoff returned true for an already-cancelled context")
+	}
+}
+
+func TestIsRetriableJIRAError(t *testing.T) {
+	rateLimited := &APIError{StatusCode: http.StatusTooManyRequests}
+	badRequest := &APIError{StatusCode: http.StatusBadRequest}
+
+	if !IsRetriableJIRAError(rateLimited) {
+		t.Errorf("IsRetriableJIRAError(%v) = false, want true", rateLimited)
+	}
+	if IsRetriableJIRAError(badRequest) {
+		t.Errorf("IsRetriableJIRAError(%v) = true, want false", badRequest)
+	}
+	if IsRetriableJIRAError(nil) {
+		t.Error("IsRetriableJIRAError(nil) = true, want false")
+	}
+}
@@ -0,0 +1,306 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/deepsourcelabs/hermes/domain"
+	"github.com/deepsourcelabs/hermes/provider/jira/auth"
+)
+
+// SearchIssuesRequest performs a JQL search via /rest/api/3/search.
+type SearchIssuesRequest struct {
+	JQL        string
+	MaxResults int
+	CloudID    string
+	Credential auth.Credential
+}
+
+// SearchIssuesResponse is the subset of /rest/api/3/search's response that
+// callers of SearchIssues need.
+type SearchIssuesResponse struct {
+	Total  int           `json:"total"`
+	Issues []SearchIssue `json:"issues"`
+}
+
+// SearchIssue is a single result row from SearchIssues.
+type SearchIssue struct {
+	Key    string            `json:"key"`
+	Fields SearchIssueFields `json:"fields"`
+}
+
+// SearchIssueFields carries the subset of issue fields grouping logic
+// needs to decide whether to comment on, reopen, or skip a matching issue.
+type SearchIssueFields struct {
+	Resolution     *resolutionField `json:"resolution"`
+	ResolutionDate jiraTime         `json:"resolutiondate"`
+}
+
+// resolutionName returns the resolution's name, or "" for an unresolved
+// issue (where JIRA sends resolution: null).
+func (f SearchIssueFields) resolutionName() string {
+	if f.Resolution == nil {
+		return ""
+	}
+	return f.Resolution.Name
+}
+
+// resolutionField is JIRA's resolution shape: null for an open issue, or an
+// object such as {"id":"10000","name":"Done"} for a resolved one. It is
+// never a bare string.
+type resolutionField struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// jiraTimeLayout matches the format JIRA emits timestamps in, e.g.
+// "2024-01-01T10:00:00.000+0000" - note the offset has no colon, so this
+// does not parse as RFC3339 and needs its own time.Time wrapper.
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// jiraTime decodes a JIRA timestamp field, which may be absent (an empty
+// string) as well as in jiraTimeLayout.
+type jiraTime struct {
+	time.Time
+}
+
+func (t *jiraTime) UnmarshalJSON(data []byte) error {
+	raw := strings.Trim(string(data), `"`)
+	if raw == "" || raw == "null" {
+		return nil
+	}
+	parsed, err := time.Parse(jiraTimeLayout, raw)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// TransitionIssueRequest moves an issue through its workflow, e.g. to
+// reopen a resolved issue for an incoming alert in the same group.
+type TransitionIssueRequest struct {
+	IssueKey     string
+	TransitionID string
+	CloudID      string
+	Credential   auth.Credential
+}
+
+// TransitionIssueResponse is returned after a successful transition. The
+// JIRA transitions endpoint itself returns no body on success, so this
+// only carries what the caller already knows about the request.
+type TransitionIssueResponse struct {
+	IssueKey     string `json:"issue_key"`
+	TransitionID string `json:"transition_id"`
+}
+
+// AddCommentRequest adds a comment to an existing issue, e.g. when a new
+// alert arrives for a group that already has an open issue.
+type AddCommentRequest struct {
+	IssueKey   string
+	Body       map[string]interface{}
+	CloudID    string
+	Credential auth.Credential
+}
+
+// AddCommentResponse is the created comment as returned by JIRA.
+type AddCommentResponse struct {
+	ID   string                 `json:"id"`
+	Body map[string]interface{} `json:"body"`
+}
+
+// SearchIssues runs a JQL query and returns the matching issues.
+func (c *Client) SearchIssues(ctx context.Context, request *SearchIssuesRequest) (*SearchIssuesResponse, domain.IError) {
+	endpoint := fmt.Sprintf("https://api.atlassian.com/ex/jira/%s/rest/api/3/search", request.CloudID)
+	query := url.Values{}
+	query.Set("jql", request.JQL)
+	query.Set("maxResults", fmt.Sprintf("%d", maxResultsOrDefault(request.MaxResults)))
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, errFailedAPICall(err.Error())
+	}
+	if err := setAuthHeader(ctx, httpRequest, request.Credential); err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Accept", "application/json")
+
+	response := new(SearchIssuesResponse)
+	if err := c.doJSON(httpRequest, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// TransitionIssue moves an issue to the workflow state identified by
+// TransitionID, e.g. reopening a resolved issue.
+func (c *Client) TransitionIssue(ctx context.Context, request *TransitionIssueRequest) (*TransitionIssueResponse, domain.IError) {
+	endpoint := fmt.Sprintf("https://api.atlassian.com/ex/jira/%s/rest/api/3/issue/%s/transitions", request.CloudID, request.IssueKey)
+	body, err := json.Marshal(map[string]interface{}{
+		"transition": map[string]string{"id": request.TransitionID},
+	})
+	if err != nil {
+		return nil, errFailedAPICall(err.Error())
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, errFailedAPICall(err.Error())
+	}
+	if err := setAuthHeader(ctx, httpRequest, request.Credential); err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	if err := c.doJSON(httpRequest, nil); err != nil {
+		return nil, err
+	}
+	return &TransitionIssueResponse{IssueKey: request.IssueKey, TransitionID: request.TransitionID}, nil
+}
+
+// AddComment posts a new comment on an existing issue.
+func (c *Client) AddComment(ctx context.Context, request *AddCommentRequest) (*AddCommentResponse, domain.IError) {
+	endpoint := fmt.Sprintf("https://api.atlassian.com/ex/jira/%s/rest/api/3/issue/%s/comment", request.CloudID, request.IssueKey)
+	body, err := json.Marshal(map[string]interface{}{"body": request.Body})
+	if err != nil {
+		return nil, errFailedAPICall(err.Error())
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, errFailedAPICall(err.Error())
+	}
+	if err := setAuthHeader(ctx, httpRequest, request.Credential); err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	response := new(AddCommentResponse)
+	if err := c.doJSON(httpRequest, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// UpdateIssueFieldsRequest sets one or more fields on an existing issue via
+// PUT /rest/api/3/issue/{key}.
+type UpdateIssueFieldsRequest struct {
+	IssueKey   string
+	Fields     map[string]interface{}
+	CloudID    string
+	Credential auth.Credential
+}
+
+// UpdateIssueFields sets fields on an existing issue, e.g. stamping the
+// group key custom field onto a newly created issue.
+func (c *Client) UpdateIssueFields(ctx context.Context, request *UpdateIssueFieldsRequest) domain.IError {
+	endpoint := fmt.Sprintf("https://api.atlassian.com/ex/jira/%s/rest/api/3/issue/%s", request.CloudID, request.IssueKey)
+	body, err := json.Marshal(map[string]interface{}{"fields": request.Fields})
+	if err != nil {
+		return errFailedAPICall(err.Error())
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errFailedAPICall(err.Error())
+	}
+	if err := setAuthHeader(ctx, httpRequest, request.Credential); err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	return c.doJSON(httpRequest, nil)
+}
+
+// setAuthHeader resolves cred.Header and applies it to request, used by
+// every Client method in this file instead of formatting a bearer token
+// inline.
+func setAuthHeader(ctx context.Context, request *http.Request, cred auth.Credential) domain.IError {
+	header, err := cred.Header(ctx)
+	if err != nil {
+		return errFailedAPICall(err.Error())
+	}
+	request.Header.Set("Authorization", header)
+	return nil
+}
+
+// doJSON executes request, retrying retriable failures (429/502/503/504
+// and temporary/timeout transport errors) with exponential backoff, and
+// decodes the JSON response body into out when out is non-nil. A nil out
+// is used for endpoints such as transitions that return 204 No Content on
+// success.
+func (c *Client) doJSON(request *http.Request, out interface{}) domain.IError {
+	cfg := c.retryConfig()
+	backoff := cfg.InitialBackoff
+
+	for attempt := 1; attempt <= cfg.MaxAttempts; attempt++ {
+		if attempt > 1 && request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return errFailedAPICall(err.Error())
+			}
+			request.Body = body
+		}
+
+		response, err := c.HTTPClient.Do(request)
+		if err != nil {
+			lastErr := errFailedAPICall(err.Error())
+			if attempt == cfg.MaxAttempts || !isRetriableTransportError(err) {
+				return lastErr
+			}
+			if !waitBackoff(request.Context(), nextBackoff(&backoff, cfg)) {
+				return lastErr
+			}
+			continue
+		}
+
+		if response.StatusCode >= http.StatusBadRequest {
+			apiErr := parseAPIError(response)
+			response.Body.Close()
+			lastErr := errFailedAPICall(apiErr.Error())
+			if attempt == cfg.MaxAttempts || !isRetriableStatus(response.StatusCode) {
+				return lastErr
+			}
+			delay := nextBackoff(&backoff, cfg)
+			if retryAfter := parseRetryAfter(response.Header.Get("Retry-After")); retryAfter > 0 {
+				delay = retryAfter
+			}
+			if !waitBackoff(request.Context(), delay) {
+				return lastErr
+			}
+			continue
+		}
+
+		if out != nil {
+			err := json.NewDecoder(response.Body).Decode(out)
+			response.Body.Close()
+			if err != nil {
+				return errFailedAPICall(err.Error())
+			}
+			return nil
+		}
+		response.Body.Close()
+		return nil
+	}
+	return errFailedAPICall("exhausted retry attempts")
+}
+
+// errFailedAPICall wraps a JIRA API transport or status error as a
+// domain.IError so Client methods can surface failures the same way
+// payload and opts validation already do.
+func errFailedAPICall(msg string) domain.IError {
+	return errFailedBodyValidation(msg)
+}
+
+func maxResultsOrDefault(maxResults int) int {
+	if maxResults <= 0 {
+		return 50
+	}
+	return maxResults
+}